@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import "k8s.io/apimachinery/pkg/runtime"
+
+// ApplicationPhase is a label for the condition of an Application at the
+// current time.
+type ApplicationPhase string
+
+// Application phases reported on ApplicationStatus.Phase.
+const (
+	ApplicationRendering      ApplicationPhase = "rendering"
+	ApplicationHealthChecking ApplicationPhase = "healthChecking"
+	ApplicationRunning        ApplicationPhase = "running"
+	ApplicationRollingOut     ApplicationPhase = "rollingOut"
+)
+
+// ApplicationComponent declares a single component of an Application, in the
+// same name/type/properties shape as an ApplicationHook so both are rendered
+// through the same component/trait definition machinery.
+type ApplicationComponent struct {
+	// Name of the component.
+	Name string `json:"name"`
+	// Type of the component, i.e. the name of a ComponentDefinition.
+	Type string `json:"type"`
+	// Properties are the component's input parameters.
+	// +optional
+	Properties runtime.RawExtension `json:"properties,omitempty"`
+}
+
+// ApplicationComponentStatus records the observed readiness of a single
+// rendered component, as reported by the Kind-aware ReadinessChecker
+// machinery (or its CUE `isHealth` fallback).
+type ApplicationComponentStatus struct {
+	// Name of the component this status is for.
+	Name string `json:"name"`
+	// Healthy reports whether the component's rendered workload has reached
+	// readiness.
+	Healthy bool `json:"healthy"`
+	// Message carries the readiness reason, e.g. "waiting for rollout: 2/3
+	// updated", whether or not the component is healthy.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
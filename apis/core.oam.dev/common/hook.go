@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// HookPhase is a lifecycle point at which an ApplicationHook runs, modeled
+// after Helm's pre/post install & upgrade hooks but extended with rollout
+// and test phases that are specific to KubeVela's progressive delivery model.
+type HookPhase string
+
+// HookTypeRaw is the only currently-supported ApplicationHook.Type: Properties
+// is applied as a raw Kubernetes object with no CUE rendering.
+const HookTypeRaw = "raw"
+
+// Lifecycle phases an ApplicationHook can be bound to.
+const (
+	HookPhasePreRender   HookPhase = "pre-render"
+	HookPhasePostRender  HookPhase = "post-render"
+	HookPhasePreApply    HookPhase = "pre-apply"
+	HookPhasePostApply   HookPhase = "post-apply"
+	HookPhasePreDelete   HookPhase = "pre-delete"
+	HookPhasePostDelete  HookPhase = "post-delete"
+	HookPhasePreRollout  HookPhase = "pre-rollout"
+	HookPhasePostRollout HookPhase = "post-rollout"
+	HookPhaseTest        HookPhase = "test"
+)
+
+// ApplicationHook declares a CUE or raw Kubernetes object that the Application
+// controller applies and waits on at a specific lifecycle Phase, similar to a
+// Helm hook but expressed as a first-class KubeVela concept so it can be
+// tracked, garbage collected and reported on like any other resource.
+type ApplicationHook struct {
+	// Name uniquely identifies this hook within the Application.
+	Name string `json:"name"`
+	// Type selects how Properties is interpreted. Only HookTypeRaw is
+	// currently supported: Properties is applied as a raw Kubernetes object
+	// verbatim. Rendering hooks from a CUE component/trait definition type
+	// (e.g. "k8s-objects") is intended for a follow-up once the hook engine
+	// can share the appfile parser's CUE pipeline.
+	Type string `json:"type"`
+	// Phase is the lifecycle point this hook is bound to.
+	Phase HookPhase `json:"phase"`
+	// Weight orders hooks within the same Phase; lower weights run first.
+	// +optional
+	Weight int `json:"weight,omitempty"`
+	// Properties is the CUE/Kubernetes object definition rendered for this hook,
+	// interpreted the same way a component or trait's properties are.
+	Properties runtime.RawExtension `json:"properties"`
+}
+
+// HookStatus reports the outcome of running a single ApplicationHook.
+type HookStatus struct {
+	// Name matches ApplicationHook.Name.
+	Name string `json:"name"`
+	// Phase this status was observed for.
+	Phase HookPhase `json:"phase"`
+	// Started is when the hook resource was first applied.
+	// +optional
+	Started *metav1.Time `json:"started,omitempty"`
+	// Completed is when the hook resource reached readiness.
+	// +optional
+	Completed *metav1.Time `json:"completed,omitempty"`
+	// Failed is when the hook was given up on.
+	// +optional
+	Failed *metav1.Time `json:"failed,omitempty"`
+	// Message carries the latest readiness reason, or the failure reason.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+)
+
+// ApplicationSpec is the spec of an Application.
+type ApplicationSpec struct {
+	// Components declare the workloads, in application-friendly terms, this
+	// Application renders and applies.
+	Components []common.ApplicationComponent `json:"components"`
+
+	// RolloutPlan, when set, causes the Application controller to drive a
+	// progressive rollout of a new revision instead of applying it in place.
+	// +optional
+	RolloutPlan *runtime.RawExtension `json:"rolloutPlan,omitempty"`
+
+	// Hooks declares CUE/Kubernetes-object hooks that run at defined
+	// lifecycle points during this Application's reconcile, similar to a
+	// Helm chart's hooks.
+	// +optional
+	Hooks []common.ApplicationHook `json:"hooks,omitempty"`
+}
+
+// ApplicationStatus is the observed state of an Application.
+type ApplicationStatus struct {
+	v1alpha1.ConditionedStatus `json:",inline"`
+
+	// Phase is the current high-level phase of the Application's reconcile.
+	Phase common.ApplicationPhase `json:"status,omitempty"`
+
+	// Services records the per-component readiness observed on the last
+	// health check pass.
+	// +optional
+	Services []common.ApplicationComponentStatus `json:"services,omitempty"`
+
+	// Components references the rendered Component objects for this
+	// Application's current revision.
+	// +optional
+	Components []v1alpha1.TypedReference `json:"components,omitempty"`
+
+	// ResourceTracker references the ResourceTracker object that garbage
+	// collects everything this Application has rendered, if one has been
+	// created.
+	// +optional
+	ResourceTracker *v1alpha1.TypedReference `json:"resourceTracker,omitempty"`
+
+	// HookStatuses records the outcome of every lifecycle hook run so far,
+	// keyed by hook name and phase.
+	// +optional
+	HookStatuses []common.HookStatus `json:"hookStatuses,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={oam}
+
+// Application is the Schema for the applications API.
+type Application struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApplicationSpec   `json:"spec,omitempty"`
+	Status ApplicationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ApplicationList contains a list of Application.
+type ApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Application `json:"items"`
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/oam-dev/kubevela/pkg/dsl/definition"
+	"github.com/oam-dev/kubevela/pkg/oam/discoverymapper"
+)
+
+// Args args for controller setup
+type Args struct {
+	// DiscoveryMapper is the discovery mapper that's used across controllers
+	DiscoveryMapper discoverymapper.DiscoveryMapper
+	// PackageDiscover is the package discover that's used across controllers
+	PackageDiscover *definition.PackageDiscover
+	// AppRevisionLimit is the maximum number of application revisions to keep
+	AppRevisionLimit int
+	// ApplicationSelector, when non-nil, restricts the Application controller to
+	// only reconcile Applications whose labels match the selector. This allows
+	// several KubeVela installations to share a cluster and each own a disjoint
+	// subset of Applications, e.g. `--application-selector="app.oam.dev/managed-by=team-a"`.
+	ApplicationSelector labels.Selector
+	// CloudEventsSinkURL, when non-empty, is the target the Application
+	// controller publishes CloudEvents v1.0 phase-transition events to, e.g.
+	// `--cloudevents-sink=http://events.observability.svc/application`.
+	// Leave empty to disable CloudEvents publishing entirely.
+	CloudEventsSinkURL string
+}
@@ -18,6 +18,8 @@ package application
 
 import (
 	"context"
+	"encoding/json"
+	"sync"
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/apis/core/v1alpha1"
@@ -28,11 +30,15 @@ import (
 	"github.com/pkg/errors"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
@@ -64,6 +70,16 @@ type Reconciler struct {
 	Recorder         event.Recorder
 	applicator       apply.Applicator
 	appRevisionLimit int
+	// selector, when non-nil, restricts reconciliation to Applications whose
+	// labels match it. This lets multiple KubeVela installations share a
+	// cluster while each only owns a subset of Applications.
+	selector labels.Selector
+	// ce publishes CloudEvents for phase transitions; nil disables it.
+	ce *eventEmitter
+	// unhealthyStreak counts, per Application, how many consecutive health
+	// checks have failed, so the requeue backoff can grow exponentially
+	// instead of retrying at a fixed interval.
+	unhealthyStreak sync.Map
 }
 
 // +kubebuilder:rbac:groups=core.oam.dev,resources=applications,verbs=get;list;watch;create;update;patch;delete
@@ -85,29 +101,41 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	}
 
 	handler := &appHandler{
-		r:      r,
-		app:    app,
-		logger: applog,
+		r:               r,
+		app:             app,
+		logger:          applog,
+		resourceTracker: &clientResourceTracker{client: r.Client},
 	}
 
-	if app.ObjectMeta.DeletionTimestamp.IsZero() {
-		if registerFinalizers(app) {
-			applog.Info("Register new finalizer", "application", app.Namespace+"/"+app.Name, "finalizers", app.ObjectMeta.Finalizers)
-			return reconcile.Result{}, errors.Wrap(r.Client.Update(ctx, app), errUpdateApplicationFinalizer)
-		}
-	} else {
-		needUpdate, err := handler.removeResourceTracker(ctx)
-		if err != nil {
-			applog.Error(err, "Failed to remove application resourceTracker")
-			app.Status.SetConditions(v1alpha1.ReconcileError(errors.Wrap(err, "error to  remove finalizer")))
-			return reconcile.Result{}, errors.Wrap(r.UpdateStatus(ctx, app), errUpdateApplicationStatus)
-		}
-		if needUpdate {
-			applog.Info("remove finalizer of application", "application", app.Namespace+"/"+app.Name, "finalizers", app.ObjectMeta.Finalizers)
-			return ctrl.Result{}, errors.Wrap(r.Update(ctx, app), errUpdateApplicationFinalizer)
+	if !app.ObjectMeta.DeletionTimestamp.IsZero() {
+		return r.cleanupApplication(ctx, handler, req.NamespacedName, applog)
+	}
+
+	// honor a late label change: even if the watch predicate let this event
+	// through, the Application may no longer match our selector by the time
+	// we actually reconcile it.
+	if !r.matchesSelector(app) {
+		if !meta.FinalizerExists(&app.ObjectMeta, resourceTrackerFinalizer) {
+			// nothing of ours to clean up: some other controller instance owns
+			// this Application now.
+			return ctrl.Result{}, nil
 		}
-		// deleting and no need to handle finalizer
-		return reconcile.Result{}, nil
+		// we still hold the resourceTracker finalizer from when this
+		// Application did match our selector. Run the same cleanup a real
+		// deletion would (instead of falling through to the normal
+		// render/apply/health-check pipeline below) so the finalizer is
+		// eventually released and the Application can still be deleted.
+		return r.cleanupApplication(ctx, handler, req.NamespacedName, applog)
+	}
+
+	if needsFinalizer(app) {
+		applog.Info("Register new finalizer", "application", app.Namespace+"/"+app.Name, "finalizers", app.ObjectMeta.Finalizers)
+		err := patchFinalizers(ctx, r.Client, app, []string{resourceTrackerFinalizer}, nil)
+		return reconcile.Result{}, errors.Wrap(err, errUpdateApplicationFinalizer)
+	}
+
+	if res, err, proceed := handler.reconcileHookPhase(ctx, common.HookPhasePreRender); !proceed {
+		return res, err
 	}
 
 	applog.Info("Start Rendering")
@@ -130,6 +158,10 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	app.Status.SetConditions(readyCondition("Parsed"))
 	handler.appfile = generatedAppfile
 
+	if res, err, proceed := handler.reconcileHookPhase(ctx, common.HookPhasePostRender); !proceed {
+		return res, err
+	}
+
 	appRev, err := handler.GenerateAppRevision(ctx)
 	if err != nil {
 		applog.Error(err, "[Handle Calculate Revision]")
@@ -138,6 +170,7 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return handler.handleErr(err)
 	}
 	r.Recorder.Event(app, event.Normal(velatypes.ReasonParsed, velatypes.MessageParsed))
+	r.emitEvent(ctx, app, applog, eventTypeParsed, applicationEventData{AppfileDigest: appfileDigest(appRev), RevisionName: appRev.Name})
 	// Record the revision so it can be used to render data in context.appRevision
 	generatedAppfile.RevisionName = appRev.Name
 
@@ -162,8 +195,19 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	// pass the App label and annotation to ac except some app specific ones
 	oamutil.PassLabelAndAnnotation(app, ac)
 
+	var compRefs []string
+	for _, comp := range comps {
+		compRefs = append(compRefs, comp.Name)
+	}
+
 	app.Status.SetConditions(readyCondition("Built"))
 	r.Recorder.Event(app, event.Normal(velatypes.ReasonRendered, velatypes.MessageRendered))
+	r.emitEvent(ctx, app, applog, eventTypeBuilt, applicationEventData{AppfileDigest: appfileDigest(appRev), RevisionName: appRev.Name, ComponentRefs: compRefs})
+
+	if res, err, proceed := handler.reconcileHookPhase(ctx, common.HookPhasePreApply); !proceed {
+		return res, err
+	}
+
 	applog.Info("apply application revision & component to the cluster")
 	// apply application revision & component to the cluster
 	if err := handler.apply(ctx, appRev, ac, comps); err != nil {
@@ -173,8 +217,18 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return handler.handleErr(err)
 	}
 
+	r.emitEvent(ctx, app, applog, eventTypeApplied, applicationEventData{AppfileDigest: appfileDigest(appRev), RevisionName: appRev.Name, ComponentRefs: compRefs})
+
+	if res, err, proceed := handler.reconcileHookPhase(ctx, common.HookPhasePostApply); !proceed {
+		return res, err
+	}
+
 	// if inplace is false and rolloutPlan is nil, it means the user will use an outer AppRollout object to rollout the application
 	if handler.app.Spec.RolloutPlan != nil {
+		if res, err, proceed := handler.reconcileHookPhase(ctx, common.HookPhasePreRollout); !proceed {
+			return res, err
+		}
+
 		res, err := handler.handleRollout(ctx)
 		if err != nil {
 			applog.Error(err, "[handle rollout]")
@@ -191,8 +245,13 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 		// there is no need reconcile immediately, that means the rollout operation have finished
 		r.Recorder.Event(app, event.Normal(velatypes.ReasonRollout, velatypes.MessageRollout))
+		r.emitEvent(ctx, app, applog, eventTypeRollout, applicationEventData{AppfileDigest: appfileDigest(appRev), RevisionName: appRev.Name})
 		app.Status.SetConditions(readyCondition("Rollout"))
 		applog.Info("rollout finished")
+
+		if res, err, proceed := handler.reconcileHookPhase(ctx, common.HookPhasePostRollout); !proceed {
+			return res, err
+		}
 	}
 
 	// The following logic will be skipped if rollout have not finished
@@ -201,7 +260,7 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	app.Status.Phase = common.ApplicationHealthChecking
 	applog.Info("check application health status")
 	// check application health status
-	appCompStatus, healthy, err := handler.statusAggregate(generatedAppfile)
+	appCompStatus, healthy, err := handler.statusAggregate(ctx, generatedAppfile)
 	if err != nil {
 		applog.Error(err, "[status aggregate]")
 		app.Status.SetConditions(errorCondition("HealthCheck", err))
@@ -212,18 +271,27 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		app.Status.SetConditions(errorCondition("HealthCheck", errors.New("not healthy")))
 
 		app.Status.Services = appCompStatus
-		// unhealthy will check again after 10s
-		return ctrl.Result{RequeueAfter: time.Second * 10}, r.Status().Update(ctx, app)
+		backoff := healthCheckBackoff(r.recordUnhealthy(req.NamespacedName))
+		applog.Info("application not healthy, backing off", "backoff", backoff)
+		return ctrl.Result{RequeueAfter: backoff}, r.Status().Update(ctx, app)
 	}
+	r.clearUnhealthy(req.NamespacedName)
 	app.Status.Services = appCompStatus
 	app.Status.SetConditions(readyCondition("HealthCheck"))
 	r.Recorder.Event(app, event.Normal(velatypes.ReasonHealthCheck, velatypes.MessageHealthCheck))
+	r.emitEvent(ctx, app, applog, eventTypeHealthCheck, applicationEventData{AppfileDigest: appfileDigest(appRev), RevisionName: appRev.Name, ComponentRefs: compRefs})
 	app.Status.Phase = common.ApplicationRunning
+	r.emitEvent(ctx, app, applog, eventTypeRunning, applicationEventData{AppfileDigest: appfileDigest(appRev), RevisionName: appRev.Name, ComponentRefs: compRefs})
+
+	if res, err, proceed := handler.reconcileHookPhase(ctx, common.HookPhaseTest); !proceed {
+		return res, err
+	}
 
 	err = garbageCollection(ctx, handler)
 	if err != nil {
 		applog.Error(err, "[Garbage collection]")
 		r.Recorder.Event(app, event.Warning(velatypes.ReasonFailedGC, err))
+		r.emitEvent(ctx, app, applog, eventTypeGCFailed, applicationEventData{AppfileDigest: appfileDigest(appRev), RevisionName: appRev.Name, ConditionMessage: err.Error()})
 	}
 
 	// Gather status of components
@@ -241,23 +309,163 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	return ctrl.Result{}, r.UpdateStatus(ctx, app)
 }
 
-// if any finalizers newly registered, return true
-func registerFinalizers(app *v1beta1.Application) bool {
-	if !meta.FinalizerExists(&app.ObjectMeta, resourceTrackerFinalizer) && app.Status.ResourceTracker != nil {
-		meta.AddFinalizer(&app.ObjectMeta, resourceTrackerFinalizer)
-		return true
+// needsFinalizer reports whether app still needs the resourceTracker
+// finalizer registered. It does not mutate app; patchFinalizers is
+// responsible for actually adding it.
+func needsFinalizer(app *v1beta1.Application) bool {
+	return !meta.FinalizerExists(&app.ObjectMeta, resourceTrackerFinalizer) && app.Status.ResourceTracker != nil
+}
+
+// cleanupApplication runs the PreDelete/PostDelete hooks, tears down the
+// Application's resourceTracker and releases the resourceTracker finalizer.
+// It is used both for an Application actually being deleted and for one that
+// no longer matches our selector but still carries our finalizer, since both
+// cases need the exact same teardown before we can let go of the object.
+func (r *Reconciler) cleanupApplication(ctx context.Context, handler *appHandler, name types.NamespacedName, applog logr.Logger) (ctrl.Result, error) {
+	app := handler.app
+	// the Application may be deleted (or stop matching our selector) while it
+	// is still unhealthy; clear its streak now so a future Application with
+	// the same name doesn't inherit a stale backoff, and so unhealthyStreak
+	// doesn't leak an entry forever.
+	r.clearUnhealthy(name)
+	if res, err, proceed := handler.reconcileHookPhase(ctx, common.HookPhasePreDelete); !proceed {
+		return res, err
+	}
+	needUpdate, err := handler.removeResourceTracker(ctx)
+	if err != nil {
+		applog.Error(err, "Failed to remove application resourceTracker")
+		app.Status.SetConditions(v1alpha1.ReconcileError(errors.Wrap(err, "error to  remove finalizer")))
+		return reconcile.Result{}, errors.Wrap(r.UpdateStatus(ctx, app), errUpdateApplicationStatus)
+	}
+	if !needUpdate {
+		// deleting and no need to handle finalizer
+		return reconcile.Result{}, nil
+	}
+	if res, err, proceed := handler.reconcileHookPhase(ctx, common.HookPhasePostDelete); !proceed {
+		return res, err
+	}
+	applog.Info("remove finalizer of application", "application", app.Namespace+"/"+app.Name, "finalizers", app.ObjectMeta.Finalizers)
+	r.emitEvent(ctx, app, applog, eventTypeDeleted, applicationEventData{})
+	err = patchFinalizers(ctx, r.Client, app, nil, []string{resourceTrackerFinalizer})
+	return ctrl.Result{}, errors.Wrap(err, errUpdateApplicationFinalizer)
+}
+
+// patchFinalizers applies add/remove to app's finalizer list via a targeted
+// JSON merge patch of only metadata.finalizers, guarded by
+// metadata.resourceVersion for optimistic concurrency, instead of a full
+// object Update. A full Update races with concurrent edits to spec/status
+// made between our Get and Update, and silently reverts them; patching just
+// the finalizer list avoids that entire class of spurious conflict and
+// reduces conflict-retry traffic on clusters with many Applications.
+func patchFinalizers(ctx context.Context, c client.Client, app *v1beta1.Application, add, remove []string) error {
+	finalizers := mergeFinalizers(app.GetFinalizers(), add, remove)
+	body, err := json.Marshal(finalizerPatch{Metadata: finalizerPatchMetadata{
+		ResourceVersion: app.ResourceVersion,
+		Finalizers:      finalizers,
+	}})
+	if err != nil {
+		return errors.Wrap(err, "cannot encode finalizer patch")
+	}
+	if err := c.Patch(ctx, app, client.RawPatch(types.MergePatchType, body)); err != nil {
+		return err
+	}
+	app.SetFinalizers(finalizers)
+	return nil
+}
+
+// finalizerPatch is the JSON merge patch body patchFinalizers sends: only
+// the two fields we intend to touch, nothing else on the object.
+type finalizerPatch struct {
+	Metadata finalizerPatchMetadata `json:"metadata"`
+}
+
+type finalizerPatchMetadata struct {
+	ResourceVersion string   `json:"resourceVersion"`
+	Finalizers      []string `json:"finalizers"`
+}
+
+// mergeFinalizers returns a copy of current with add appended (if absent)
+// and remove dropped, preserving order and de-duplicating.
+func mergeFinalizers(current, add, remove []string) []string {
+	skip := make(map[string]bool, len(remove))
+	for _, f := range remove {
+		skip[f] = true
+	}
+	seen := make(map[string]bool, len(current)+len(add))
+	merged := make([]string, 0, len(current)+len(add))
+	for _, f := range append(append([]string{}, current...), add...) {
+		if skip[f] || seen[f] {
+			continue
+		}
+		seen[f] = true
+		merged = append(merged, f)
+	}
+	return merged
+}
+
+// recordUnhealthy increments and returns the number of consecutive failed
+// health checks observed for name, used to grow the requeue backoff.
+func (r *Reconciler) recordUnhealthy(name types.NamespacedName) int {
+	count, _ := r.unhealthyStreak.LoadOrStore(name, 0)
+	next := count.(int) + 1
+	r.unhealthyStreak.Store(name, next)
+	return next
+}
+
+// clearUnhealthy resets the unhealthy streak for name once it becomes
+// healthy again.
+func (r *Reconciler) clearUnhealthy(name types.NamespacedName) {
+	r.unhealthyStreak.Delete(name)
+}
+
+// matchesSelector reports whether the Application is owned by this
+// controller instance. A nil selector matches everything, preserving the
+// default single-tenant behavior.
+func (r *Reconciler) matchesSelector(app *v1beta1.Application) bool {
+	return r.selector == nil || r.selector.Matches(labels.Set(app.GetLabels()))
+}
+
+// applicationSelectorPredicate filters watch events so we never enqueue
+// Applications outside our selector in the first place; Reconcile still
+// re-checks the selector to handle labels changing after an event was queued.
+func applicationSelectorPredicate(selector labels.Selector) predicate.Predicate {
+	matches := func(obj runtime.Object) bool {
+		app, ok := obj.(*v1beta1.Application)
+		return ok && (selector == nil || selector.Matches(labels.Set(app.GetLabels())))
+	}
+	return predicate.Funcs{
+		CreateFunc: func(e ctrlevent.CreateEvent) bool {
+			return matches(e.Object)
+		},
+		UpdateFunc: func(e ctrlevent.UpdateEvent) bool {
+			return matches(e.ObjectNew)
+		},
+		DeleteFunc: func(e ctrlevent.DeleteEvent) bool {
+			return matches(e.Object)
+		},
+		GenericFunc: func(e ctrlevent.GenericEvent) bool {
+			return matches(e.Object)
+		},
 	}
-	return false
 }
 
 // SetupWithManager install to manager
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// If Application Own these two child objects, AC status change will notify application controller and recursively update AC again, and trigger application event again...
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1beta1.Application{}).
+		For(&v1beta1.Application{}, builder.WithPredicates(applicationSelectorPredicate(r.selector))).
 		Complete(r)
 }
 
+// emitEvent publishes a CloudEvents phase-transition event through the
+// configured sink, if any, logging rather than failing the reconcile if
+// delivery fails: the sink is an observability side channel.
+func (r *Reconciler) emitEvent(ctx context.Context, app *v1beta1.Application, applog logr.Logger, eventType applicationEventType, data applicationEventData) {
+	if err := r.ce.emit(ctx, app, eventType, data); err != nil {
+		applog.Error(err, "[CloudEvents]", "type", eventType)
+	}
+}
+
 // UpdateStatus updates v1beta1.Application's Status with retry.RetryOnConflict
 func (r *Reconciler) UpdateStatus(ctx context.Context, app *v1beta1.Application, opts ...client.UpdateOption) error {
 	status := app.DeepCopy().Status
@@ -272,6 +480,10 @@ func (r *Reconciler) UpdateStatus(ctx context.Context, app *v1beta1.Application,
 
 // Setup adds a controller that reconciles AppRollout.
 func Setup(mgr ctrl.Manager, args core.Args, _ logging.Logger) error {
+	ce, err := newEventEmitter(args, "Application")
+	if err != nil {
+		return errors.Wrap(err, "cannot set up CloudEvents sink")
+	}
 	reconciler := Reconciler{
 		Client:           mgr.GetClient(),
 		Log:              ctrl.Log.WithName("Application"),
@@ -281,6 +493,8 @@ func Setup(mgr ctrl.Manager, args core.Args, _ logging.Logger) error {
 		pd:               args.PackageDiscover,
 		applicator:       apply.NewAPIApplicator(mgr.GetClient()),
 		appRevisionLimit: args.AppRevisionLimit,
+		selector:         args.ApplicationSelector,
+		ce:               ce,
 	}
 	return reconciler.SetupWithManager(mgr)
 }
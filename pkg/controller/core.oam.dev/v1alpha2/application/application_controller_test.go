@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+)
+
+func TestMatchesSelector(t *testing.T) {
+	mustSelector := func(s string) labels.Selector {
+		sel, err := labels.Parse(s)
+		if err != nil {
+			t.Fatalf("cannot parse selector %q: %v", s, err)
+		}
+		return sel
+	}
+
+	cases := map[string]struct {
+		selector labels.Selector
+		labels   map[string]string
+		want     bool
+	}{
+		"nil selector matches everything": {
+			selector: nil,
+			labels:   map[string]string{"team": "other"},
+			want:     true,
+		},
+		"matching label": {
+			selector: mustSelector("team=platform"),
+			labels:   map[string]string{"team": "platform"},
+			want:     true,
+		},
+		"non-matching label": {
+			selector: mustSelector("team=platform"),
+			labels:   map[string]string{"team": "other"},
+			want:     false,
+		},
+		"no labels never match a non-nil selector": {
+			selector: mustSelector("team=platform"),
+			labels:   nil,
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{selector: tc.selector}
+			app := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Labels: tc.labels}}
+			if got := r.matchesSelector(app); got != tc.want {
+				t.Errorf("matchesSelector() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestApplicationSelectorPredicate(t *testing.T) {
+	sel, err := labels.Parse("team=platform")
+	if err != nil {
+		t.Fatalf("cannot parse selector: %v", err)
+	}
+	pred := applicationSelectorPredicate(sel)
+
+	matching := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "platform"}}}
+	other := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "other"}}}
+
+	if !pred.Create(ctrlevent.CreateEvent{Object: matching}) {
+		t.Error("CreateFunc: expected matching Application to pass the predicate")
+	}
+	if pred.Create(ctrlevent.CreateEvent{Object: other}) {
+		t.Error("CreateFunc: expected non-matching Application to be filtered out")
+	}
+	if !pred.Update(ctrlevent.UpdateEvent{ObjectOld: other, ObjectNew: matching}) {
+		t.Error("UpdateFunc: expected predicate to check ObjectNew, not ObjectOld")
+	}
+	if !pred.Delete(ctrlevent.DeleteEvent{Object: matching}) {
+		t.Error("DeleteFunc: expected matching Application to pass the predicate")
+	}
+}
+
+func TestMergeFinalizers(t *testing.T) {
+	cases := map[string]struct {
+		current, add, remove []string
+		want                 []string
+	}{
+		"add a new finalizer": {
+			current: []string{"a"},
+			add:     []string{"b"},
+			want:    []string{"a", "b"},
+		},
+		"adding an existing finalizer is a no-op": {
+			current: []string{"a", "b"},
+			add:     []string{"b"},
+			want:    []string{"a", "b"},
+		},
+		"remove a finalizer": {
+			current: []string{"a", "b"},
+			remove:  []string{"a"},
+			want:    []string{"b"},
+		},
+		"removing an absent finalizer is a no-op": {
+			current: []string{"a"},
+			remove:  []string{"b"},
+			want:    []string{"a"},
+		},
+		"remove wins over add for the same finalizer": {
+			current: []string{"a"},
+			add:     []string{"b"},
+			remove:  []string{"b"},
+			want:    []string{"a"},
+		},
+		"order is preserved": {
+			current: []string{"b", "a"},
+			add:     []string{"c"},
+			want:    []string{"b", "a", "c"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := mergeFinalizers(tc.current, tc.add, tc.remove)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("mergeFinalizers(%v, %v, %v) = %v, want %v", tc.current, tc.add, tc.remove, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/pkg/errors"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	core "github.com/oam-dev/kubevela/pkg/controller/core.oam.dev"
+)
+
+// eventEmitterTimeout bounds how long a single CloudEvents publish may block
+// the calling reconcile goroutine. The sink is an observability side
+// channel, not a correctness dependency: an unreachable or slow-to-respond
+// sink must fail fast rather than stall reconciliation for every Application
+// sharing this workqueue worker.
+const eventEmitterTimeout = 10 * time.Second
+
+// applicationEventType is the CloudEvents `type` published for an Application
+// phase transition, namespaced under "dev.oam.application" so subscribers can
+// filter on a single prefix regardless of which phase fired.
+type applicationEventType string
+
+// Event types published at each phase transition the reconciler already
+// reports via r.Recorder. Values follow CloudEvents' reverse-DNS convention.
+const (
+	eventTypeParsed      applicationEventType = "dev.oam.application.parsed.v1"
+	eventTypeBuilt       applicationEventType = "dev.oam.application.built.v1"
+	eventTypeApplied     applicationEventType = "dev.oam.application.applied.v1"
+	eventTypeRollout     applicationEventType = "dev.oam.application.rollout.v1"
+	eventTypeHealthCheck applicationEventType = "dev.oam.application.health-check.v1"
+	eventTypeRunning     applicationEventType = "dev.oam.application.running.v1"
+	eventTypeGCFailed    applicationEventType = "dev.oam.application.gc-failed.v1"
+	eventTypeDeleted     applicationEventType = "dev.oam.application.deleted.v1"
+)
+
+// applicationEventData is the CloudEvents JSON data payload for an
+// Application phase transition.
+type applicationEventData struct {
+	AppfileDigest    string   `json:"appfileDigest,omitempty"`
+	RevisionName     string   `json:"revisionName,omitempty"`
+	ComponentRefs    []string `json:"componentRefs,omitempty"`
+	ConditionMessage string   `json:"conditionMessage,omitempty"`
+}
+
+// appfileDigest returns a SHA-256 digest of appRev's rendered spec (the
+// Application spec plus the component/trait/workload/policy/scope
+// definitions snapshotted for this revision), so subscribers can tell
+// whether two revisions actually rendered to the same Appfile content
+// without fetching and diffing the AppRevision themselves. Falls back to an
+// empty string if the revision's spec cannot be marshalled, which should
+// never happen for a well-formed AppRevision.
+func appfileDigest(appRev *v1beta1.ApplicationRevision) string {
+	raw, err := json.Marshal(appRev.Spec)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// eventEmitter publishes CloudEvents for Application lifecycle transitions to
+// an optional HTTP sink so downstream systems such as audit trails, GitOps
+// notifiers or chat bots can subscribe without polling the API server. A nil
+// *eventEmitter is always a safe no-op, so wiring it in is optional
+// per-Reconciler. Other transports (Kafka, PubSub, ...) are not implemented;
+// add a constructor alongside newEventEmitter if one is needed.
+type eventEmitter struct {
+	client cloudevents.Client
+	source string
+}
+
+// newEventEmitter builds an eventEmitter from core.Args, or returns nil if no
+// sink was configured.
+func newEventEmitter(args core.Args, controllerName string) (*eventEmitter, error) {
+	if args.CloudEventsSinkURL == "" {
+		return nil, nil
+	}
+	p, err := cloudevents.NewHTTP(
+		cloudevents.WithTarget(args.CloudEventsSinkURL),
+		cloudevents.WithClient(http.Client{Timeout: eventEmitterTimeout}),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create CloudEvents HTTP protocol")
+	}
+	c, err := cloudevents.NewClient(p, cloudevents.WithTimeNow(), cloudevents.WithUUIDs())
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create CloudEvents client")
+	}
+	return &eventEmitter{client: c, source: controllerName}, nil
+}
+
+// emit publishes a single phase-transition event. Failures are swallowed into
+// a log-friendly error rather than failing the reconcile: the CloudEvents
+// sink is an observability side channel, not a correctness dependency.
+func (e *eventEmitter) emit(ctx context.Context, app *v1beta1.Application, eventType applicationEventType, data applicationEventData) error {
+	if e == nil {
+		return nil
+	}
+	event := cloudevents.NewEvent()
+	event.SetType(string(eventType))
+	event.SetSource(e.source)
+	event.SetSubject(app.Namespace + "/" + app.Name)
+	id := data.RevisionName
+	if id == "" {
+		// eventTypeDeleted (and any other event fired without an AppRevision to
+		// hand to) has no RevisionName to key off; fall back to the
+		// Application's UID so the ID is still stable across retries instead of
+		// being silently left blank.
+		id = string(app.UID) + "-" + string(eventType)
+	}
+	event.SetID(id)
+	if err := event.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		return errors.Wrap(err, "cannot encode CloudEvents payload")
+	}
+	result := e.client.Send(ctx, event)
+	if cloudevents.IsUndelivered(result) {
+		return errors.Wrap(result, "cannot deliver CloudEvents event")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+)
+
+func TestNilEventEmitterIsANoOp(t *testing.T) {
+	var e *eventEmitter
+	app := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"}}
+
+	if err := e.emit(context.Background(), app, eventTypeApplied, applicationEventData{}); err != nil {
+		t.Errorf("expected a nil *eventEmitter to be a safe no-op, got err=%v", err)
+	}
+}
+
+func TestAppfileDigestStableForSameSpec(t *testing.T) {
+	revA := &v1beta1.ApplicationRevision{}
+	revA.Spec.Application = v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+	revB := &v1beta1.ApplicationRevision{}
+	revB.Spec.Application = v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+	revC := &v1beta1.ApplicationRevision{}
+	revC.Spec.Application = v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Name: "bar"}}
+
+	digestA := appfileDigest(revA)
+	digestB := appfileDigest(revB)
+	digestC := appfileDigest(revC)
+
+	if digestA == "" {
+		t.Fatal("expected a non-empty digest for a well-formed AppRevision")
+	}
+	if digestA != digestB {
+		t.Errorf("expected two revisions with the same rendered spec to produce the same digest, got %q and %q", digestA, digestB)
+	}
+	if digestA == digestC {
+		t.Errorf("expected revisions with different rendered specs to produce different digests")
+	}
+}
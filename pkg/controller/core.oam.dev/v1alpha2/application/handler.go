@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/appfile"
+)
+
+// appHandler carries the state a single Reconcile pass needs to thread
+// through rendering, applying, rollout and health checking without
+// recomputing it at every step.
+type appHandler struct {
+	r       *Reconciler
+	app     *v1beta1.Application
+	logger  logr.Logger
+	appfile *appfile.Appfile
+	// resourceTracker registers hook-rendered objects for garbage collection
+	// alongside the Application's components and traits.
+	resourceTracker resourceTracker
+}
+
+// resourceTracker registers an arbitrary rendered object as belonging to app,
+// so it is cleaned up the same way components and traits are.
+type resourceTracker interface {
+	Track(ctx context.Context, app *v1beta1.Application, obj *unstructured.Unstructured) error
+}
+
+// clientResourceTracker labels tracked objects with the owning Application's
+// identity, the same convention handleResourceTracker uses for components and
+// traits, so garbageCollection can find and reap them by listing on it.
+type clientResourceTracker struct {
+	client client.Client
+}
+
+// trackingLabelKey and trackingLabelNamespaceKey together mark a resource as
+// owned by an Application, so it can be found and garbage collected by
+// namespace/name, the same way components and traits rendered by the main
+// reconcile path are tracked. Two labels are used because a label *value*
+// cannot contain "/" - only a label *key* may have a "/"-separated prefix -
+// so a single "namespace/name" value is rejected by the API server.
+const (
+	trackingLabelKey          = "app.oam.dev/resourceTracker"
+	trackingLabelNamespaceKey = "app.oam.dev/resourceTrackerNamespace"
+)
+
+// Track implements resourceTracker by stamping obj with labels identifying
+// its owning Application, via a merge patch of only metadata.labels rather
+// than a full Update. A full Update would race with the object's own
+// controller bumping its status between our Apply and this call, failing
+// with a spurious 409 conflict and leaving the object applied but never
+// labeled - permanently invisible to a later garbageCollection listing by
+// this label. The same reasoning as patchFinalizers in
+// application_controller.go.
+func (t *clientResourceTracker) Track(ctx context.Context, app *v1beta1.Application, obj *unstructured.Unstructured) error {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[trackingLabelKey] = app.Name
+	labels[trackingLabelNamespaceKey] = app.Namespace
+
+	body, err := json.Marshal(trackingLabelPatch{Metadata: trackingLabelPatchMetadata{Labels: labels}})
+	if err != nil {
+		return errors.Wrap(err, "cannot encode tracking label patch")
+	}
+	if err := t.client.Patch(ctx, obj, client.RawPatch(types.MergePatchType, body)); err != nil {
+		return errors.Wrapf(err, "cannot label %s %q for tracking", obj.GetKind(), obj.GetName())
+	}
+	obj.SetLabels(labels)
+	return nil
+}
+
+// trackingLabelPatch is the JSON merge patch body Track sends: only
+// metadata.labels, nothing else on the object.
+type trackingLabelPatch struct {
+	Metadata trackingLabelPatchMetadata `json:"metadata"`
+}
+
+type trackingLabelPatchMetadata struct {
+	Labels map[string]string `json:"labels"`
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	velatypes "github.com/oam-dev/kubevela/apis/types"
+)
+
+// hookRequeueWaitTime is how long to wait before re-checking a hook that
+// has not yet reached readiness.
+const hookRequeueWaitTime = time.Second * 5
+
+// runHooks renders and applies every ApplicationHook bound to phase, in
+// ascending weight order, blocking on each one's readiness before moving to
+// the next. It returns requeue=true when a hook is still waiting to become
+// ready, in which case the caller should stop processing the current phase
+// and let the next reconcile pick up where this one left off.
+func (h *appHandler) runHooks(ctx context.Context, phase common.HookPhase) (requeue bool, err error) {
+	hooks := hooksForPhase(h.app.Spec.Hooks, phase)
+	if len(hooks) == 0 {
+		return false, nil
+	}
+	sort.SliceStable(hooks, func(i, j int) bool { return hooks[i].Weight < hooks[j].Weight })
+
+	for _, hook := range hooks {
+		ready, message, err := h.runHook(ctx, hook)
+		if err != nil {
+			h.recordHookFailure(hook, err)
+			return false, errors.Wrapf(err, "hook %q (%s)", hook.Name, phase)
+		}
+		if !ready {
+			h.recordHookStatus(hook, message, false)
+			return true, nil
+		}
+		h.recordHookStatus(hook, message, true)
+	}
+	return false, nil
+}
+
+// runHook applies a single hook's resource (tracked by the resourceTracker
+// for GC, same as any other rendered object) and checks its readiness.
+//
+// Only common.HookTypeRaw is supported: Properties is applied as a raw
+// Kubernetes object verbatim. Rendering a hook from a component/trait
+// definition's CUE template is intended for a follow-up once the hook engine
+// can share the appfile parser's CUE pipeline.
+func (h *appHandler) runHook(ctx context.Context, hook common.ApplicationHook) (ready bool, message string, err error) {
+	if hook.Type != common.HookTypeRaw {
+		return false, "", errors.Errorf("hook %q: unsupported type %q, only %q is supported", hook.Name, hook.Type, common.HookTypeRaw)
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(hook.Properties.Raw); err != nil {
+		return false, "", errors.Wrapf(err, "cannot parse hook %q object", hook.Name)
+	}
+
+	if err := h.r.applicator.Apply(ctx, obj); err != nil {
+		return false, "", errors.Wrapf(err, "cannot apply hook %q", hook.Name)
+	}
+	if err := h.trackHookResource(ctx, obj); err != nil {
+		return false, "", errors.Wrapf(err, "cannot track hook %q for GC", hook.Name)
+	}
+
+	// Delegate the actual readiness decision to the same per-GVK checkers the
+	// main reconcile loop uses, so a hook that is a Job, Deployment, etc. is
+	// held to the same bar as a regular workload. Raw hook objects carry no
+	// CUE healthPolicy, so pass none: unregistered kinds fall back to
+	// "ready once applied", same as before.
+	return checkObjectReadiness(ctx, h.r.Client, obj, "")
+}
+
+// reconcileHookPhase runs every hook bound to phase and translates the
+// outcome into the (ctrl.Result, error) shape Reconcile returns everywhere
+// else. proceed is false whenever the caller should return (res, err)
+// immediately instead of continuing the reconcile.
+func (h *appHandler) reconcileHookPhase(ctx context.Context, phase common.HookPhase) (res ctrl.Result, err error, proceed bool) {
+	requeue, err := h.runHooks(ctx, phase)
+	if err != nil {
+		res, err = h.handleErr(err)
+		return res, err, false
+	}
+	if requeue {
+		return ctrl.Result{RequeueAfter: hookRequeueWaitTime}, h.r.UpdateStatus(ctx, h.app), false
+	}
+	return ctrl.Result{}, nil, true
+}
+
+// hooksForPhase filters the Application's declared hooks down to the ones
+// bound to phase.
+func hooksForPhase(hooks []common.ApplicationHook, phase common.HookPhase) []common.ApplicationHook {
+	var matched []common.ApplicationHook
+	for _, hook := range hooks {
+		if hook.Phase == phase {
+			matched = append(matched, hook)
+		}
+	}
+	return matched
+}
+
+// recordHookStatus upserts the HookStatus entry for hook into app.Status,
+// stamping Started on first sight and Completed once it becomes ready.
+func (h *appHandler) recordHookStatus(hook common.ApplicationHook, message string, ready bool) {
+	now := metav1.Now()
+	for i := range h.app.Status.HookStatuses {
+		status := &h.app.Status.HookStatuses[i]
+		if status.Name != hook.Name || status.Phase != hook.Phase {
+			continue
+		}
+		status.Message = message
+		if status.Started == nil {
+			status.Started = &now
+		}
+		if ready && status.Completed == nil {
+			status.Completed = &now
+		}
+		return
+	}
+	status := common.HookStatus{Name: hook.Name, Phase: hook.Phase, Started: &now, Message: message}
+	if ready {
+		status.Completed = &now
+	}
+	h.app.Status.HookStatuses = append(h.app.Status.HookStatuses, status)
+}
+
+// recordHookFailure marks a hook as failed, emits a Warning event and sets
+// the HookFailed condition so the reconcile requeues rather than proceeding.
+func (h *appHandler) recordHookFailure(hook common.ApplicationHook, err error) {
+	now := metav1.Now()
+	for i := range h.app.Status.HookStatuses {
+		status := &h.app.Status.HookStatuses[i]
+		if status.Name == hook.Name && status.Phase == hook.Phase {
+			if status.Started == nil {
+				status.Started = &now
+			}
+			status.Failed = &now
+			status.Message = err.Error()
+			h.app.Status.SetConditions(errorCondition(hookFailedCondition, err))
+			h.r.Recorder.Event(h.app, event.Warning(velatypes.ReasonFailedApply, err))
+			return
+		}
+	}
+	h.app.Status.HookStatuses = append(h.app.Status.HookStatuses, common.HookStatus{
+		Name: hook.Name, Phase: hook.Phase, Started: &now, Failed: &now, Message: err.Error(),
+	})
+	h.app.Status.SetConditions(errorCondition(hookFailedCondition, err))
+	h.r.Recorder.Event(h.app, event.Warning(velatypes.ReasonFailedApply, err))
+}
+
+// hookFailedCondition is the condition type set on the Application when a
+// lifecycle hook fails outright (as opposed to merely still waiting).
+const hookFailedCondition = "HookFailed"
+
+// trackHookResource registers a hook's rendered object with the same
+// resourceTracker used for components and traits, so it is garbage
+// collected alongside the rest of the Application's resources.
+func (h *appHandler) trackHookResource(ctx context.Context, obj *unstructured.Unstructured) error {
+	return h.resourceTracker.Track(ctx, h.app, obj)
+}
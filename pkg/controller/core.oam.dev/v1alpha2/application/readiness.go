@@ -0,0 +1,290 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReadinessChecker reports whether a rendered object has reached a ready
+// state, mirroring Helm's kube.Wait/statuscheck package: most built-in
+// workload kinds have a precise, Kind-specific notion of "ready" that beats
+// waiting on a generic condition.
+type ReadinessChecker interface {
+	// Check inspects the live object (already fetched) and returns whether it
+	// is ready, along with a human-readable reason either way (e.g. "waiting
+	// for rollout: 2/3 updated").
+	Check(obj *unstructured.Unstructured) (ready bool, reason string, err error)
+}
+
+// ReadinessCheckerFunc adapts a function to a ReadinessChecker.
+type ReadinessCheckerFunc func(obj *unstructured.Unstructured) (bool, string, error)
+
+// Check implements ReadinessChecker.
+func (f ReadinessCheckerFunc) Check(obj *unstructured.Unstructured) (bool, string, error) {
+	return f(obj)
+}
+
+var (
+	readinessCheckersMu sync.RWMutex
+	readinessCheckers   = map[schema.GroupVersionKind]ReadinessChecker{}
+)
+
+// RegisterReadinessChecker registers a ReadinessChecker for gvk, overriding
+// any previously registered checker for the same GVK. Trait and component
+// definition authors can use this to contribute Kind-specific readiness
+// semantics for their own CRDs instead of relying on the CUE `isHealth`
+// fallback.
+func RegisterReadinessChecker(gvk schema.GroupVersionKind, checker ReadinessChecker) {
+	readinessCheckersMu.Lock()
+	defer readinessCheckersMu.Unlock()
+	readinessCheckers[gvk] = checker
+}
+
+func init() {
+	RegisterReadinessChecker(appsv1.SchemeGroupVersion.WithKind("Deployment"), ReadinessCheckerFunc(deploymentReady))
+	RegisterReadinessChecker(appsv1.SchemeGroupVersion.WithKind("StatefulSet"), ReadinessCheckerFunc(statefulSetReady))
+	RegisterReadinessChecker(appsv1.SchemeGroupVersion.WithKind("DaemonSet"), ReadinessCheckerFunc(daemonSetReady))
+	RegisterReadinessChecker(batchv1.SchemeGroupVersion.WithKind("Job"), ReadinessCheckerFunc(jobReady))
+	RegisterReadinessChecker(corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"), ReadinessCheckerFunc(pvcReady))
+	RegisterReadinessChecker(corev1.SchemeGroupVersion.WithKind("Service"), ReadinessCheckerFunc(serviceReady))
+	RegisterReadinessChecker(corev1.SchemeGroupVersion.WithKind("Pod"), ReadinessCheckerFunc(podReady))
+}
+
+// checkObjectReadiness fetches the live state of obj and runs the
+// ReadinessChecker registered for its GVK, falling back to evaluating the
+// component/trait definition's own CUE `isHealth` expression (healthPolicy)
+// against the live object when no Kind-specific checker is registered. An
+// empty healthPolicy (nothing declared for this kind) keeps the previous
+// default of treating the object as ready once applied.
+func checkObjectReadiness(ctx context.Context, c client.Client, obj *unstructured.Unstructured, healthPolicy string) (ready bool, reason string, err error) {
+	live := obj.DeepCopy()
+	key := apitypes.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	if err := c.Get(ctx, key, live); err != nil {
+		return false, "", err
+	}
+
+	readinessCheckersMu.RLock()
+	checker, ok := readinessCheckers[live.GroupVersionKind()]
+	readinessCheckersMu.RUnlock()
+	if !ok {
+		return isHealthCondition(live, healthPolicy)
+	}
+	return checker.Check(live)
+}
+
+// isHealthCondition is the fallback readiness check for kinds without a
+// registered ReadinessChecker: it compiles healthPolicy - the component or
+// trait definition's `status.healthPolicy` CUE snippet, the same one the
+// render path uses - with the live object bound to `output`, and reads the
+// `isHealth` boolean (and optional `message`) it produces. This is not a
+// generic "Ready" status condition: healthPolicy is an arbitrary CUE boolean
+// over the live resource, so a Kind can report healthy from any field it
+// likes (replica counts, a custom status field, etc.), not just conditions.
+func isHealthCondition(obj *unstructured.Unstructured, healthPolicy string) (ready bool, reason string, err error) {
+	if healthPolicy == "" {
+		// no health policy declared for this kind: treat the object as ready
+		// once applied, same as the render path's own default.
+		return true, "applied", nil
+	}
+	raw, err := obj.MarshalJSON()
+	if err != nil {
+		return false, "", errors.Wrap(err, "cannot marshal live object for healthPolicy evaluation")
+	}
+	cc := cuecontext.New()
+	v := cc.CompileString("output: " + string(raw) + "\n" + healthPolicy)
+	if err := v.Err(); err != nil {
+		return false, "", errors.Wrap(err, "cannot evaluate healthPolicy CUE")
+	}
+	isHealth, err := v.LookupPath(cue.ParsePath("isHealth")).Bool()
+	if err != nil {
+		return false, "", errors.Wrap(err, "healthPolicy did not produce a boolean isHealth")
+	}
+	reason, _ = v.LookupPath(cue.ParsePath("message")).String()
+	if !isHealth && reason == "" {
+		reason = "not healthy"
+	}
+	return isHealth, reason, nil
+}
+
+func deploymentReady(obj *unstructured.Unstructured) (bool, string, error) {
+	d := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, d); err != nil {
+		return false, "", err
+	}
+	if d.Status.ObservedGeneration != d.Generation {
+		return false, "waiting for spec update to be observed", nil
+	}
+	for _, cond := range d.Status.Conditions {
+		// a Progressing condition stuck on ProgressDeadlineExceeded will never
+		// self-heal without operator intervention; treat it the same as a
+		// terminally failed Job instead of "still waiting" forever.
+		if cond.Type == appsv1.DeploymentProgressing && cond.Status == corev1.ConditionFalse && cond.Reason == "ProgressDeadlineExceeded" {
+			return false, cond.Message, errors.Errorf("deployment rollout failed: %s", cond.Message)
+		}
+	}
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	if d.Status.UpdatedReplicas < desired {
+		return false, fmt.Sprintf("waiting for rollout: %d/%d updated", d.Status.UpdatedReplicas, desired), nil
+	}
+	if d.Status.AvailableReplicas < desired {
+		return false, fmt.Sprintf("waiting for rollout: %d/%d available", d.Status.AvailableReplicas, desired), nil
+	}
+	return true, "rollout complete", nil
+}
+
+func statefulSetReady(obj *unstructured.Unstructured) (bool, string, error) {
+	s := &appsv1.StatefulSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, s); err != nil {
+		return false, "", err
+	}
+	if s.Status.ObservedGeneration != s.Generation {
+		return false, "waiting for spec update to be observed", nil
+	}
+	desired := int32(1)
+	if s.Spec.Replicas != nil {
+		desired = *s.Spec.Replicas
+	}
+	if s.Status.ReadyReplicas < desired {
+		return false, fmt.Sprintf("waiting for rollout: %d/%d ready", s.Status.ReadyReplicas, desired), nil
+	}
+	if s.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		// OnDelete intentionally leaves old-revision pods running until an
+		// operator deletes them one at a time: UpdateRevision != CurrentRevision
+		// is the expected steady state, not a rollout still in progress.
+		return true, "rollout complete", nil
+	}
+	partition := int32(0)
+	if s.Spec.UpdateStrategy.RollingUpdate != nil && s.Spec.UpdateStrategy.RollingUpdate.Partition != nil {
+		partition = *s.Spec.UpdateStrategy.RollingUpdate.Partition
+	}
+	if partition == 0 && s.Status.UpdateRevision != s.Status.CurrentRevision {
+		return false, "waiting for all replicas to be updated", nil
+	}
+	return true, "rollout complete", nil
+}
+
+func daemonSetReady(obj *unstructured.Unstructured) (bool, string, error) {
+	d := &appsv1.DaemonSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, d); err != nil {
+		return false, "", err
+	}
+	if d.Status.ObservedGeneration != d.Generation {
+		return false, "waiting for spec update to be observed", nil
+	}
+	if d.Status.NumberReady < d.Status.DesiredNumberScheduled {
+		return false, fmt.Sprintf("waiting for rollout: %d/%d ready", d.Status.NumberReady, d.Status.DesiredNumberScheduled), nil
+	}
+	return true, "rollout complete", nil
+}
+
+func jobReady(obj *unstructured.Unstructured) (bool, string, error) {
+	j := &batchv1.Job{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, j); err != nil {
+		return false, "", err
+	}
+	for _, cond := range j.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+			// a Failed Job will never become ready on its own: surface this as a
+			// terminal error (not "still waiting") so callers like runHook stop
+			// requeuing and record the failure instead.
+			return false, cond.Message, errors.Errorf("job failed: %s", cond.Message)
+		}
+		if cond.Type == batchv1.JobComplete && cond.Status == corev1.ConditionTrue {
+			return true, "job complete", nil
+		}
+	}
+	return false, "waiting for job to complete", nil
+}
+
+func pvcReady(obj *unstructured.Unstructured) (bool, string, error) {
+	p := &corev1.PersistentVolumeClaim{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, p); err != nil {
+		return false, "", err
+	}
+	if p.Status.Phase != corev1.ClaimBound {
+		return false, fmt.Sprintf("waiting for claim to be bound: phase=%s", p.Status.Phase), nil
+	}
+	return true, "bound", nil
+}
+
+func serviceReady(obj *unstructured.Unstructured) (bool, string, error) {
+	s := &corev1.Service{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, s); err != nil {
+		return false, "", err
+	}
+	if s.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return true, "service created", nil
+	}
+	if len(s.Status.LoadBalancer.Ingress) == 0 {
+		return false, "waiting for load balancer ingress to be assigned", nil
+	}
+	return true, "load balancer ready", nil
+}
+
+func podReady(obj *unstructured.Unstructured) (bool, string, error) {
+	p := &corev1.Pod{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, p); err != nil {
+		return false, "", err
+	}
+	for _, cond := range p.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			if cond.Status == corev1.ConditionTrue {
+				return true, "ready", nil
+			}
+			return false, cond.Message, nil
+		}
+	}
+	return false, "waiting for Ready condition", nil
+}
+
+// healthCheckBackoff returns the requeue delay for the given number of
+// consecutive unhealthy health checks, doubling from 1s up to a 30s cap
+// instead of the previous fixed 10s wait.
+func healthCheckBackoff(consecutiveUnhealthy int) time.Duration {
+	const (
+		base       = time.Second
+		maxBackoff = 30 * time.Second
+	)
+	shift := consecutiveUnhealthy - 1
+	if shift < 0 {
+		shift = 0
+	}
+	d := base << shift
+	if d <= 0 || d > maxBackoff { // guard against overflow for large counts
+		return maxBackoff
+	}
+	return d
+}
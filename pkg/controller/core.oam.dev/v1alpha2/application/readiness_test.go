@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func toUnstructured(t *testing.T, obj runtime.Object) *unstructured.Unstructured {
+	t.Helper()
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		t.Fatalf("cannot convert to unstructured: %v", err)
+	}
+	return &unstructured.Unstructured{Object: m}
+}
+
+func TestJobReadyTerminalFailure(t *testing.T) {
+	job := &batchv1.Job{
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"},
+			},
+		},
+	}
+
+	ready, reason, err := jobReady(toUnstructured(t, job))
+	if ready {
+		t.Error("expected a Failed Job to never be reported ready")
+	}
+	if err == nil {
+		t.Fatal("expected a Failed Job to be reported as a terminal error, not just \"still waiting\"")
+	}
+	if reason != "BackoffLimitExceeded" {
+		t.Errorf("reason = %q, want the JobFailed condition message", reason)
+	}
+}
+
+func TestJobReadyStillRunning(t *testing.T) {
+	job := &batchv1.Job{}
+	ready, _, err := jobReady(toUnstructured(t, job))
+	if ready || err != nil {
+		t.Errorf("expected a Job with no conditions yet to be \"still waiting\" (ready=false, err=nil), got ready=%v err=%v", ready, err)
+	}
+}
+
+func TestDeploymentReadyTerminalFailure(t *testing.T) {
+	deploy := &appsv1.Deployment{
+		Status: appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded", Message: "rollout timed out"},
+			},
+		},
+	}
+
+	ready, _, err := deploymentReady(toUnstructured(t, deploy))
+	if ready {
+		t.Error("expected a Deployment stuck on ProgressDeadlineExceeded to never be reported ready")
+	}
+	if err == nil {
+		t.Fatal("expected a stuck rollout to be reported as a terminal error, not just \"still waiting\"")
+	}
+}
+
+func TestDeploymentReadyStillRollingOut(t *testing.T) {
+	replicas := int32(3)
+	deploy := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue},
+			},
+			UpdatedReplicas: 1,
+		},
+	}
+
+	ready, _, err := deploymentReady(toUnstructured(t, deploy))
+	if ready || err != nil {
+		t.Errorf("expected a rollout still in progress to be \"still waiting\" (ready=false, err=nil), got ready=%v err=%v", ready, err)
+	}
+}
+
+func TestHealthCheckBackoff(t *testing.T) {
+	cases := map[string]struct {
+		consecutiveUnhealthy int
+		want                 string
+	}{
+		"first failure":             {1, "1s"},
+		"second failure doubles":    {2, "2s"},
+		"third failure doubles":     {3, "4s"},
+		"zero treated as first":     {0, "1s"},
+		"negative treated as first": {-1, "1s"},
+		"caps at 30s":               {10, "30s"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := healthCheckBackoff(tc.consecutiveUnhealthy).String(); got != tc.want {
+				t.Errorf("healthCheckBackoff(%d) = %s, want %s", tc.consecutiveUnhealthy, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsHealthConditionNoPolicy(t *testing.T) {
+	obj := toUnstructured(t, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "foo"}})
+	ready, _, err := isHealthCondition(obj, "")
+	if err != nil || !ready {
+		t.Errorf("expected an empty healthPolicy to default to ready=true, got ready=%v err=%v", ready, err)
+	}
+}
+
+func TestIsHealthConditionEvaluatesCUEPolicy(t *testing.T) {
+	obj := toUnstructured(t, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: 2},
+	})
+
+	ready, _, err := isHealthCondition(obj, `isHealth: output.status.readyReplicas > 0`)
+	if err != nil {
+		t.Fatalf("unexpected error evaluating healthPolicy: %v", err)
+	}
+	if !ready {
+		t.Error("expected isHealth to evaluate true for readyReplicas=2")
+	}
+
+	ready, reason, err := isHealthCondition(obj, `isHealth: output.status.readyReplicas > 10
+message: "not enough ready replicas"`)
+	if err != nil {
+		t.Fatalf("unexpected error evaluating healthPolicy: %v", err)
+	}
+	if ready {
+		t.Error("expected isHealth to evaluate false for readyReplicas=2 > 10")
+	}
+	if reason != "not enough ready replicas" {
+		t.Errorf("reason = %q, want the healthPolicy's message", reason)
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/common"
+	"github.com/oam-dev/kubevela/pkg/appfile"
+)
+
+// statusAggregate checks every workload rendered by af against the Kind-aware
+// ReadinessChecker registry (falling back to the CUE `isHealth` condition for
+// kinds without one), and reports per-component readiness instead of a single
+// opaque healthy/unhealthy bit.
+func (h *appHandler) statusAggregate(ctx context.Context, af *appfile.Appfile) ([]common.ApplicationComponentStatus, bool, error) {
+	var services []common.ApplicationComponentStatus
+	healthy := true
+	for _, wl := range af.Workloads {
+		obj := wl.GetObject()
+		ready, reason, err := checkObjectReadiness(ctx, h.r.Client, obj, wl.HealthPolicy)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "cannot check readiness of component %q", wl.Name)
+		}
+		if !ready {
+			healthy = false
+		}
+		services = append(services, common.ApplicationComponentStatus{
+			Name:    wl.Name,
+			Healthy: ready,
+			Message: reason,
+		})
+	}
+	return services, healthy, nil
+}